@@ -0,0 +1,177 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// CycloneDXWriter implements Writer and emits a CycloneDX SBOM, optionally
+// enriched with the vulnerabilities detected for each component.
+type CycloneDXWriter struct {
+	Output io.Writer
+	Format string // FormatCycloneDX (XML) or FormatCycloneDXJSON
+}
+
+// Write writes the report as a CycloneDX BOM on the Output.
+func (cw CycloneDXWriter) Write(report types.Report) error {
+	bom := cw.marshal(report)
+
+	fileFormat := cdx.BOMFileFormatXML
+	if cw.Format == FormatCycloneDXJSON {
+		fileFormat = cdx.BOMFileFormatJSON
+	}
+
+	encoder := cdx.NewBOMEncoder(cw.Output, fileFormat)
+	encoder.SetPretty(true)
+	if err := encoder.Encode(bom); err != nil {
+		return fmt.Errorf("failed to encode CycloneDX BOM: %w", err)
+	}
+	return nil
+}
+
+func (cw CycloneDXWriter) marshal(report types.Report) *cdx.BOM {
+	bom := cdx.NewBOM()
+	bom.SpecVersion = cdx.SpecVersion1_4
+
+	rootRef := bomRef(report.ArtifactName, "")
+	bom.Metadata = &cdx.Metadata{
+		Component: &cdx.Component{
+			BOMRef: rootRef,
+			Type:   cdx.ComponentTypeContainer,
+			Name:   report.ArtifactName,
+		},
+	}
+
+	var components []cdx.Component
+	var vulns []cdx.Vulnerability
+
+	for _, result := range report.Results {
+		// Each scanned target becomes an application component, and the
+		// packages it contains become its nested sub-components.
+		resultRef := bomRef(result.Target, string(result.Class))
+		component := cdx.Component{
+			BOMRef: resultRef,
+			Type:   cdx.ComponentTypeApplication,
+			Name:   result.Target,
+			Properties: &[]cdx.Property{
+				{Name: "aquasecurity:trivy:Class", Value: string(result.Class)},
+				{Name: "aquasecurity:trivy:Type", Value: result.Type},
+			},
+		}
+
+		var pkgComponents []cdx.Component
+		for _, pkg := range result.Packages {
+			pkgComponents = append(pkgComponents, cw.pkgComponent(pkg, result.Class))
+		}
+		if len(pkgComponents) > 0 {
+			component.Components = &pkgComponents
+		}
+		components = append(components, component)
+
+		for _, v := range result.Vulnerabilities {
+			vulns = append(vulns, cw.vulnerability(v))
+		}
+	}
+
+	bom.Components = &components
+	bom.Vulnerabilities = &vulns
+	return bom
+}
+
+func (cw CycloneDXWriter) pkgComponent(pkg types.Package, class types.ResultClass) cdx.Component {
+	return cdx.Component{
+		BOMRef:     bomRef(pkg.Name, pkg.Version),
+		Type:       cdx.ComponentTypeLibrary,
+		Name:       pkg.Name,
+		Version:    pkg.Version,
+		PackageURL: packageURL(pkg),
+		Properties: &[]cdx.Property{
+			{Name: "aquasecurity:trivy:Class", Value: string(class)},
+			{Name: "aquasecurity:trivy:LayerDigest", Value: pkg.Layer.Digest},
+			{Name: "aquasecurity:trivy:LayerDiffID", Value: pkg.Layer.DiffID},
+		},
+	}
+}
+
+func (cw CycloneDXWriter) vulnerability(v types.DetectedVulnerability) cdx.Vulnerability {
+	vuln := cdx.Vulnerability{
+		ID:          v.VulnerabilityID,
+		Description: v.Description,
+		Affects: &[]cdx.Affects{
+			// bomRef must match the BOMRef pkgComponent assigned the affected
+			// package's component, not the scanned target, so consumers can
+			// resolve exactly which component is vulnerable.
+			{Ref: bomRef(v.PkgName, v.InstalledVersion)},
+		},
+	}
+
+	if ratings := cw.ratings(v); len(ratings) > 0 {
+		vuln.Ratings = &ratings
+	}
+
+	if v.PrimaryURL != "" {
+		vuln.Advisories = &[]cdx.Advisory{
+			{URL: v.PrimaryURL},
+		}
+	}
+
+	return vuln
+}
+
+// ratings builds one CycloneDX rating per CVSS source the vulnerability DB
+// carries (e.g. nvd, ghsa), falling back to the bare Severity when no CVSS
+// score was recorded at all.
+func (cw CycloneDXWriter) ratings(v types.DetectedVulnerability) []cdx.VulnerabilityRating {
+	var ratings []cdx.VulnerabilityRating
+	for sourceID, cvss := range v.CVSS {
+		source := &cdx.Source{Name: string(sourceID)}
+		switch {
+		case cvss.V3Score > 0:
+			score := cvss.V3Score
+			ratings = append(ratings, cdx.VulnerabilityRating{
+				Source:   source,
+				Score:    &score,
+				Severity: cdx.Severity(strings.ToLower(v.Severity)),
+				Method:   cdx.ScoringMethodCVSSv3,
+				Vector:   cvss.V3Vector,
+			})
+		case cvss.V2Score > 0:
+			score := cvss.V2Score
+			ratings = append(ratings, cdx.VulnerabilityRating{
+				Source:   source,
+				Score:    &score,
+				Severity: cdx.Severity(strings.ToLower(v.Severity)),
+				Method:   cdx.ScoringMethodCVSSv2,
+				Vector:   cvss.V2Vector,
+			})
+		}
+	}
+
+	if len(ratings) == 0 && v.Severity != "" {
+		ratings = append(ratings, cdx.VulnerabilityRating{
+			Severity: cdx.Severity(strings.ToLower(v.Severity)),
+			Method:   cdx.ScoringMethodOther,
+		})
+	}
+	return ratings
+}
+
+// bomRef builds a stable bom-ref for a named, optionally versioned, entity.
+func bomRef(name, version string) string {
+	if version == "" {
+		return name
+	}
+	return fmt.Sprintf("%s@%s", name, version)
+}
+
+func packageURL(pkg types.Package) string {
+	if pkg.Version == "" {
+		return ""
+	}
+	return fmt.Sprintf("pkg:generic/%s@%s", pkg.Name, pkg.Version)
+}
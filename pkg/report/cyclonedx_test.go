@@ -0,0 +1,61 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestReportWriter_CycloneDX(t *testing.T) {
+	input := types.Report{
+		ArtifactName: "alpine:3.16",
+		Results: types.Results{
+			{
+				Target: "alpine:3.16 (alpine 3.16.2)",
+				Class:  types.ClassOSPkg,
+				Type:   "alpine",
+				Packages: []types.Package{
+					{
+						Name:    "musl",
+						Version: "1.2.3",
+						Layer: types.Layer{
+							Digest: "sha256:dummy",
+							DiffID: "sha256:dummy-diff",
+						},
+					},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2022-1234",
+						PkgName:          "musl",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "1.2.4",
+						PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2022-1234",
+					},
+				},
+			},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	err := report.Write(input, report.Option{
+		Format: report.FormatCycloneDXJSON,
+		Output: &buf,
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `"bomFormat": "CycloneDX"`)
+	assert.Contains(t, out, `"name": "musl"`)
+	assert.Contains(t, out, `"id": "CVE-2022-1234"`)
+
+	// The vulnerability must affect musl's own bom-ref, not the scanned
+	// target's application component.
+	assert.Contains(t, out, `"ref": "musl@1.2.3"`)
+	assert.NotContains(t, out, `"ref": "alpine:3.16 (alpine 3.16.2)"`)
+}
@@ -0,0 +1,240 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// DiffOp classifies how a finding changed between two reports.
+type DiffOp string
+
+const (
+	DiffAdded           = DiffOp("added")
+	DiffRemoved         = DiffOp("removed")
+	DiffUnchanged       = DiffOp("unchanged")
+	DiffSeverityChanged = DiffOp("severity_changed")
+)
+
+// DiffEntry is a single finding paired (or not) across the base and revised
+// reports, along with the operation that pairing represents.
+type DiffEntry struct {
+	Op               DiffOp                          `json:"op"`
+	Target           string                          `json:"target"`
+	Vulnerability    *types.DetectedVulnerability    `json:"vulnerability,omitempty"`
+	Misconfiguration *types.DetectedMisconfiguration `json:"misconfiguration,omitempty"`
+	PreviousSeverity string                          `json:"previousSeverity,omitempty"`
+}
+
+// vulnKey pairs vulnerabilities by Target+PkgID+VulnerabilityID. FixedVersion
+// and PrimaryURL are deliberately excluded: a FixedVersion list getting
+// reordered, or an advisory URL showing up after being initially empty,
+// shouldn't make an otherwise identical finding look like a new one.
+func vulnKey(target string, v types.DetectedVulnerability) string {
+	return strings.Join([]string{target, v.PkgID, v.VulnerabilityID}, "\x00")
+}
+
+// misconfKey pairs misconfigurations by Target (the file)+Type+ID.
+func misconfKey(target string, m types.DetectedMisconfiguration) string {
+	return strings.Join([]string{target, m.Type, m.ID}, "\x00")
+}
+
+// Diff compares base against revised and writes the classified findings
+// according to opt.Format ("table", "json", or "github").
+func Diff(base, revised types.Report, opt Option) error {
+	entries := diffReports(base, revised)
+
+	switch opt.Format {
+	case FormatJSON:
+		return writeDiffJSON(opt.Output, entries)
+	case FormatGitHub:
+		return writeDiffGitHub(opt.Output, entries)
+	default:
+		return writeDiffTable(opt.Output, entries)
+	}
+}
+
+func diffReports(base, revised types.Report) []DiffEntry {
+	baseVulns := map[string]types.DetectedVulnerability{}
+	baseTargets := map[string]string{}
+	for _, result := range base.Results {
+		for _, v := range result.Vulnerabilities {
+			baseVulns[vulnKey(result.Target, v)] = v
+			baseTargets[vulnKey(result.Target, v)] = result.Target
+		}
+	}
+	baseMisconfs := map[string]types.DetectedMisconfiguration{}
+	for _, result := range base.Results {
+		for _, m := range result.Misconfigurations {
+			baseMisconfs[misconfKey(result.Target, m)] = m
+		}
+	}
+
+	var entries []DiffEntry
+	seenVulns := map[string]bool{}
+	seenMisconfs := map[string]bool{}
+
+	for _, result := range revised.Results {
+		for _, v := range result.Vulnerabilities {
+			key := vulnKey(result.Target, v)
+			seenVulns[key] = true
+			v := v
+
+			baseV, ok := baseVulns[key]
+			if !ok {
+				entries = append(entries, DiffEntry{Op: DiffAdded, Target: result.Target, Vulnerability: &v})
+				continue
+			}
+			if baseV.Severity != v.Severity {
+				entries = append(entries, DiffEntry{
+					Op:               DiffSeverityChanged,
+					Target:           result.Target,
+					Vulnerability:    &v,
+					PreviousSeverity: baseV.Severity,
+				})
+				continue
+			}
+			entries = append(entries, DiffEntry{Op: DiffUnchanged, Target: result.Target, Vulnerability: &v})
+		}
+
+		for _, m := range result.Misconfigurations {
+			key := misconfKey(result.Target, m)
+			seenMisconfs[key] = true
+			m := m
+
+			if _, ok := baseMisconfs[key]; !ok {
+				entries = append(entries, DiffEntry{Op: DiffAdded, Target: result.Target, Misconfiguration: &m})
+				continue
+			}
+			entries = append(entries, DiffEntry{Op: DiffUnchanged, Target: result.Target, Misconfiguration: &m})
+		}
+	}
+
+	for key, v := range baseVulns {
+		if seenVulns[key] {
+			continue
+		}
+		v := v
+		entries = append(entries, DiffEntry{Op: DiffRemoved, Target: baseTargets[key], Vulnerability: &v})
+	}
+	for _, result := range base.Results {
+		for _, m := range result.Misconfigurations {
+			key := misconfKey(result.Target, m)
+			if seenMisconfs[key] {
+				continue
+			}
+			m := m
+			entries = append(entries, DiffEntry{Op: DiffRemoved, Target: result.Target, Misconfiguration: &m})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Target != entries[j].Target {
+			return entries[i].Target < entries[j].Target
+		}
+		return diffEntryID(entries[i]) < diffEntryID(entries[j])
+	})
+
+	return entries
+}
+
+func diffEntryID(e DiffEntry) string {
+	if e.Vulnerability != nil {
+		return e.Vulnerability.VulnerabilityID
+	}
+	if e.Misconfiguration != nil {
+		return e.Misconfiguration.ID
+	}
+	return ""
+}
+
+func writeDiffTable(output io.Writer, entries []DiffEntry) error {
+	for _, e := range entries {
+		prefix := diffPrefix(e.Op)
+		id := diffEntryID(e)
+		severity := ""
+		if e.Vulnerability != nil {
+			severity = e.Vulnerability.Severity
+		} else if e.Misconfiguration != nil {
+			severity = e.Misconfiguration.Severity
+		}
+
+		switch e.Op {
+		case DiffSeverityChanged:
+			fmt.Fprintf(output, "%s %s\t%s\t%s -> %s\n", prefix, e.Target, id, e.PreviousSeverity, severity)
+		case DiffUnchanged:
+			// Unchanged findings are noise in a PR diff; omit them from the table.
+		default:
+			fmt.Fprintf(output, "%s %s\t%s\t%s\n", prefix, e.Target, id, severity)
+		}
+	}
+	return nil
+}
+
+func diffPrefix(op DiffOp) string {
+	switch op {
+	case DiffAdded:
+		return "+"
+	case DiffRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+func writeDiffJSON(output io.Writer, entries []DiffEntry) error {
+	enc := json.NewEncoder(output)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+func writeDiffGitHub(output io.Writer, entries []DiffEntry) error {
+	var added, removed, changed int
+	for _, e := range entries {
+		switch e.Op {
+		case DiffAdded:
+			added++
+		case DiffRemoved:
+			removed++
+		case DiffSeverityChanged:
+			changed++
+		}
+	}
+
+	fmt.Fprintf(output, "### Trivy diff\n\n")
+	fmt.Fprintf(output, "| Added | Removed | Severity changed |\n")
+	fmt.Fprintf(output, "|---|---|---|\n")
+	fmt.Fprintf(output, "| %d | %d | %d |\n\n", added, removed, changed)
+
+	for _, e := range entries {
+		if e.Op == DiffUnchanged {
+			continue
+		}
+		fmt.Fprintf(output, "- %s `%s` in `%s`\n", diffPrefix(e.Op), diffEntryID(e), e.Target)
+	}
+	return nil
+}
+
+// HasNewHighSeverity reports whether entries contains an added or
+// severity-escalated HIGH/CRITICAL finding, the condition CI should gate on.
+func HasNewHighSeverity(entries []DiffEntry) bool {
+	for _, e := range entries {
+		if e.Op != DiffAdded && e.Op != DiffSeverityChanged {
+			continue
+		}
+		severity := ""
+		if e.Vulnerability != nil {
+			severity = e.Vulnerability.Severity
+		} else if e.Misconfiguration != nil {
+			severity = e.Misconfiguration.Severity
+		}
+		if severity == "HIGH" || severity == "CRITICAL" {
+			return true
+		}
+	}
+	return false
+}
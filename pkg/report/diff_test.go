@@ -0,0 +1,109 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestDiff(t *testing.T) {
+	base := types.Report{
+		Results: types.Results{
+			{
+				Target: "app/package-lock.json",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2021-0001", PkgID: "foo@1.0.0", Severity: "MEDIUM"},
+					{VulnerabilityID: "CVE-2021-0002", PkgID: "bar@2.0.0", Severity: "LOW"},
+				},
+			},
+		},
+	}
+	revised := types.Report{
+		Results: types.Results{
+			{
+				Target: "app/package-lock.json",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2021-0001", PkgID: "foo@1.0.0", Severity: "HIGH"},
+					{VulnerabilityID: "CVE-2021-9999", PkgID: "baz@1.0.0", Severity: "CRITICAL"},
+				},
+			},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	err := report.Write(revised, report.Option{
+		Format:     report.FormatDiff,
+		Output:     &buf,
+		BaseReport: &base,
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "+ app/package-lock.json\tCVE-2021-9999")
+	assert.Contains(t, out, "- app/package-lock.json\tCVE-2021-0002")
+	assert.Contains(t, out, "MEDIUM -> HIGH")
+}
+
+func TestDiff_JSONAndGitHubFormats(t *testing.T) {
+	base := types.Report{
+		Results: types.Results{
+			{
+				Target: "app/package-lock.json",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2021-0001", PkgID: "foo@1.0.0", Severity: "MEDIUM"},
+				},
+			},
+		},
+	}
+	revised := types.Report{
+		Results: types.Results{
+			{
+				Target: "app/package-lock.json",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2021-9999", PkgID: "baz@1.0.0", Severity: "CRITICAL"},
+				},
+			},
+		},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		err := report.Write(revised, report.Option{
+			Format:     report.FormatJSON,
+			Output:     &buf,
+			BaseReport: &base,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"op": "added"`)
+	})
+
+	t.Run("github", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		err := report.Write(revised, report.Option{
+			Format:     report.FormatGitHub,
+			Output:     &buf,
+			BaseReport: &base,
+		})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "### Trivy diff")
+		assert.Contains(t, buf.String(), "CVE-2021-9999")
+	})
+}
+
+func TestHasNewHighSeverity(t *testing.T) {
+	entries := []report.DiffEntry{
+		{Op: report.DiffAdded, Vulnerability: &types.DetectedVulnerability{Severity: "LOW"}},
+	}
+	assert.False(t, report.HasNewHighSeverity(entries))
+
+	entries = append(entries, report.DiffEntry{
+		Op:            report.DiffAdded,
+		Vulnerability: &types.DetectedVulnerability{Severity: "CRITICAL"},
+	})
+	assert.True(t, report.HasNewHighSeverity(entries))
+}
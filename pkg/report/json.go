@@ -0,0 +1,36 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// JSONWriter implements Writer and writes the scan results as JSON.
+type JSONWriter struct {
+	Output io.Writer
+
+	// ShowSuppressed includes each Result's Suppressed vulnerabilities in
+	// the output; otherwise they're stripped before marshalling.
+	ShowSuppressed bool
+}
+
+// Write writes the results as JSON on the Output
+func (jw JSONWriter) Write(report types.Report) error {
+	if !jw.ShowSuppressed {
+		for i := range report.Results {
+			report.Results[i].Suppressed = nil
+		}
+	}
+
+	output, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if _, err = jw.Output.Write(output); err != nil {
+		return err
+	}
+	return nil
+}
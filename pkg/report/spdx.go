@@ -0,0 +1,303 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	spdxVersion     = "SPDX-2.3"
+	spdxDataLicense = "CC0-1.0"
+	noAssertion     = "NOASSERTION"
+)
+
+// spdxDocument is a minimal representation of the SPDX 2.3 JSON schema,
+// covering the fields SPDXWriter populates.
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	Packages          []spdxPackage      `json:"packages"`
+	Files             []spdxFile         `json:"files,omitempty"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxFile struct {
+	SPDXID             string   `json:"SPDXID"`
+	FileName           string   `json:"fileName"`
+	LicenseInfoInFiles []string `json:"licenseInfoInFiles,omitempty"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+	RelationshipType   string `json:"relationshipType"`
+}
+
+// SPDXWriter implements Writer and emits an SPDX document describing the
+// packages, files, and dependency relationships found during the scan.
+type SPDXWriter struct {
+	Output io.Writer
+	Format string // FormatSPDXJSON or FormatSPDXTagValue
+}
+
+// Write writes the report as an SPDX document on the Output.
+func (sw SPDXWriter) Write(report types.Report) error {
+	doc := sw.marshal(report)
+
+	switch sw.Format {
+	case FormatSPDXTagValue:
+		return writeSPDXTagValue(sw.Output, doc)
+	default:
+		enc := json.NewEncoder(sw.Output)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	}
+}
+
+func (sw SPDXWriter) marshal(report types.Report) *spdxDocument {
+	docSPDXID := "SPDXRef-DOCUMENT"
+	artifactSPDXID := elementID("artifact", report.ArtifactName)
+
+	doc := &spdxDocument{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       spdxDataLicense,
+		SPDXID:            docSPDXID,
+		Name:              report.ArtifactName,
+		DocumentNamespace: fmt.Sprintf("https://aquasecurity.github.io/trivy/spdx/%s", report.ArtifactName),
+		Packages: []spdxPackage{
+			sw.spdxPackage(types.Package{Name: report.ArtifactName}, artifactSPDXID),
+		},
+		Relationships: []spdxRelationship{
+			{
+				SPDXElementID:      docSPDXID,
+				RelatedSPDXElement: artifactSPDXID,
+				RelationshipType:   "DESCRIBES",
+			},
+		},
+	}
+
+	for _, result := range report.Results {
+		// packageSPDXIDs tracks every package declared in doc.Packages so
+		// the DEPENDS_ON relationships built from PkgParents below always
+		// point at a real packages[] entry instead of a dangling ref.
+		packageSPDXIDs := map[string]string{}
+
+		for _, pkg := range result.Packages {
+			pkgSPDXID := elementID("package", pkg.Name, pkg.Version)
+			packageSPDXIDs[pkgNameVersionKey(pkg.Name, pkg.Version)] = pkgSPDXID
+			doc.Packages = append(doc.Packages, sw.spdxPackage(pkg, pkgSPDXID))
+
+			if pkg.PkgPath != "" {
+				fileSPDXID := elementID("file", pkg.PkgPath)
+				doc.Files = append(doc.Files, spdxFile{
+					SPDXID:             fileSPDXID,
+					FileName:           pkg.PkgPath,
+					LicenseInfoInFiles: licensesOrNoAssertion(pkg.Licenses),
+				})
+				doc.Relationships = append(doc.Relationships, spdxRelationship{
+					SPDXElementID:      pkgSPDXID,
+					RelatedSPDXElement: fileSPDXID,
+					RelationshipType:   "CONTAINS",
+				})
+			}
+
+			doc.Relationships = append(doc.Relationships, spdxRelationship{
+				SPDXElementID:      artifactSPDXID,
+				RelatedSPDXElement: pkgSPDXID,
+				RelationshipType:   "CONTAINS",
+			})
+		}
+
+		// The dependency tree attached to each vulnerability (and rendered
+		// as the "Vulnerability origin graph" by the table writer) is the
+		// only place the package graph survives today, so it doubles as
+		// the source of DEPENDS_ON relationships here.
+		for _, v := range result.Vulnerabilities {
+			pkgSPDXID, ok := packageSPDXIDs[pkgNameVersionKey(v.PkgName, v.InstalledVersion)]
+			if !ok {
+				// The vulnerable package wasn't in result.Packages (e.g. a
+				// scanner that only reports findings, not a full package
+				// list): declare it so the relationship below has somewhere
+				// to point.
+				pkgSPDXID = elementID("package", v.PkgName, v.InstalledVersion)
+				packageSPDXIDs[pkgNameVersionKey(v.PkgName, v.InstalledVersion)] = pkgSPDXID
+				doc.Packages = append(doc.Packages, sw.spdxPackage(types.Package{
+					Name:    v.PkgName,
+					Version: v.InstalledVersion,
+				}, pkgSPDXID))
+			}
+
+			for _, parent := range v.PkgParents {
+				doc.Relationships = append(doc.Relationships, sw.dependsOnRelationships(doc, packageSPDXIDs, pkgSPDXID, parent)...)
+			}
+		}
+	}
+
+	return doc
+}
+
+func pkgNameVersionKey(name, version string) string {
+	return name + "@" + version
+}
+
+// dependsOnRelationships walks a dependency chain rooted at the vulnerable
+// package and emits one DEPENDS_ON edge per hop. PkgParents records the
+// packages that pulled childSPDXID in, so each parent is the dependent and
+// childSPDXID (or the previous parent) is what it depends on. declared tracks
+// every package already added to doc.Packages (keyed the same way as
+// packageSPDXIDs in marshal) so a parent walked here is declared exactly
+// once instead of left as a dangling ref.
+func (sw SPDXWriter) dependsOnRelationships(doc *spdxDocument, declared map[string]string, childSPDXID string, parent *types.DependencyTreeItem) []spdxRelationship {
+	if parent == nil {
+		return nil
+	}
+
+	parentSPDXID, ok := declared[parent.ID]
+	if !ok {
+		parentSPDXID = elementID("package", parent.ID)
+		declared[parent.ID] = parentSPDXID
+		name, version := splitPkgID(parent.ID)
+		doc.Packages = append(doc.Packages, sw.spdxPackage(types.Package{Name: name, Version: version}, parentSPDXID))
+	}
+
+	rels := []spdxRelationship{
+		{
+			SPDXElementID:      parentSPDXID,
+			RelatedSPDXElement: childSPDXID,
+			RelationshipType:   "DEPENDS_ON",
+		},
+	}
+	for _, grandParent := range parent.Parents {
+		rels = append(rels, sw.dependsOnRelationships(doc, declared, parentSPDXID, grandParent)...)
+	}
+	return rels
+}
+
+// splitPkgID splits a "name@version" package id (the convention used by
+// DetectedVulnerability.PkgID and DependencyTreeItem.ID) into its parts. An
+// id without an "@" is treated as a bare name with no version.
+func splitPkgID(id string) (name, version string) {
+	if i := strings.LastIndex(id, "@"); i >= 0 {
+		return id[:i], id[i+1:]
+	}
+	return id, ""
+}
+
+func (sw SPDXWriter) spdxPackage(pkg types.Package, spdxID string) spdxPackage {
+	concluded := pkg.LicenseConcluded
+	if concluded == "" {
+		concluded = noAssertion
+	}
+	declared := pkg.LicenseDeclared
+	if declared == "" {
+		declared = noAssertion
+	}
+
+	p := spdxPackage{
+		SPDXID:           spdxID,
+		Name:             pkg.Name,
+		VersionInfo:      pkg.Version,
+		DownloadLocation: noAssertion,
+		FilesAnalyzed:    false,
+		LicenseConcluded: concluded,
+		LicenseDeclared:  declared,
+	}
+
+	if pkg.Version != "" {
+		p.ExternalRefs = []spdxExternalRef{
+			{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  packageURL(pkg),
+			},
+		}
+	}
+	return p
+}
+
+func licensesOrNoAssertion(licenses []string) []string {
+	if len(licenses) == 0 {
+		return []string{noAssertion}
+	}
+	return licenses
+}
+
+func elementID(kind string, parts ...string) string {
+	id := kind
+	for _, p := range parts {
+		if p == "" {
+			continue
+		}
+		id += "-" + p
+	}
+	return "SPDXRef-" + sanitizeSPDXID(id)
+}
+
+func sanitizeSPDXID(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			out = append(out, r)
+		default:
+			out = append(out, '-')
+		}
+	}
+	return string(out)
+}
+
+func writeSPDXTagValue(output io.Writer, doc *spdxDocument) error {
+	w := func(format string, args ...any) {
+		fmt.Fprintf(output, format, args...)
+	}
+
+	w("SPDXVersion: %s\n", doc.SPDXVersion)
+	w("DataLicense: %s\n", doc.DataLicense)
+	w("SPDXID: %s\n", doc.SPDXID)
+	w("DocumentName: %s\n", doc.Name)
+	w("DocumentNamespace: %s\n\n", doc.DocumentNamespace)
+
+	for _, pkg := range doc.Packages {
+		w("PackageName: %s\n", pkg.Name)
+		w("SPDXID: %s\n", pkg.SPDXID)
+		w("PackageVersion: %s\n", pkg.VersionInfo)
+		w("PackageDownloadLocation: %s\n", pkg.DownloadLocation)
+		w("PackageLicenseConcluded: %s\n", pkg.LicenseConcluded)
+		w("PackageLicenseDeclared: %s\n\n", pkg.LicenseDeclared)
+	}
+
+	for _, f := range doc.Files {
+		w("FileName: %s\n", f.FileName)
+		w("SPDXID: %s\n\n", f.SPDXID)
+	}
+
+	for _, rel := range doc.Relationships {
+		w("Relationship: %s %s %s\n", rel.SPDXElementID, rel.RelationshipType, rel.RelatedSPDXElement)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestReportWriter_SPDX(t *testing.T) {
+	input := types.Report{
+		ArtifactName: "package-lock.json",
+		Results: types.Results{
+			{
+				Target: "package-lock.json",
+				Class:  types.ClassLangPkg,
+				Type:   "npm",
+				Packages: []types.Package{
+					{Name: "node-fetch", Version: "1.7.3"},
+				},
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2022-0235",
+						PkgName:          "node-fetch",
+						InstalledVersion: "1.7.3",
+						PkgParents: []*types.DependencyTreeItem{
+							{ID: "isomorphic-fetch@2.2.1"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	err := report.Write(input, report.Option{
+		Format: report.FormatSPDXJSON,
+		Output: &buf,
+	})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `"name": "node-fetch"`)
+	assert.Contains(t, out, `"relationshipType": "DEPENDS_ON"`)
+	assert.Contains(t, out, `"relationshipType": "DESCRIBES"`)
+
+	var doc struct {
+		SPDXID   string `json:"SPDXID"`
+		Packages []struct {
+			SPDXID string `json:"SPDXID"`
+			Name   string `json:"name"`
+		} `json:"packages"`
+		Relationships []struct {
+			SPDXElementID      string `json:"spdxElementId"`
+			RelatedSPDXElement string `json:"relatedSpdxElement"`
+			RelationshipType   string `json:"relationshipType"`
+		} `json:"relationships"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	var nodeFetchID string
+	for _, pkg := range doc.Packages {
+		if pkg.Name == "node-fetch" {
+			nodeFetchID = pkg.SPDXID
+		}
+	}
+	require.NotEmpty(t, nodeFetchID)
+
+	// isomorphic-fetch pulled node-fetch in, so it is the dependent: it
+	// must be the source of the DEPENDS_ON edge, node-fetch the target.
+	var found bool
+	for _, rel := range doc.Relationships {
+		if rel.RelationshipType != "DEPENDS_ON" {
+			continue
+		}
+		found = true
+		assert.Contains(t, rel.SPDXElementID, "isomorphic-fetch")
+		assert.Equal(t, nodeFetchID, rel.RelatedSPDXElement)
+	}
+	assert.True(t, found, "expected a DEPENDS_ON relationship")
+
+	// Every relationship endpoint, including the DESCRIBES target and
+	// isomorphic-fetch (never listed in result.Packages), must resolve to a
+	// declared element, i.e. the document itself (the DESCRIBES source) or a
+	// package: an SPDX validator rejects dangling element refs.
+	declared := map[string]bool{doc.SPDXID: true}
+	for _, pkg := range doc.Packages {
+		declared[pkg.SPDXID] = true
+	}
+	for _, rel := range doc.Relationships {
+		assert.True(t, declared[rel.SPDXElementID], "dangling ref: %s", rel.SPDXElementID)
+		assert.True(t, declared[rel.RelatedSPDXElement], "dangling ref: %s", rel.RelatedSPDXElement)
+	}
+}
@@ -0,0 +1,213 @@
+// Package suppress implements a vulnerability suppression (false-positive)
+// database that can be applied to a scan result before it's rendered,
+// moving triaged findings into Result.Suppressed instead of dropping them
+// so audits can still see why a finding was hidden.
+package suppress
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// TriageState records the outcome of reviewing a finding, aligned with the
+// states used by VEX tooling.
+type TriageState string
+
+const (
+	FalsePositive      = TriageState("FalsePositive")
+	NotAffected        = TriageState("NotAffected")
+	Fixed              = TriageState("Fixed")
+	UnderInvestigation = TriageState("UnderInvestigation")
+)
+
+// Justification mirrors the VEX status justifications
+// (https://www.cisa.gov/sites/default/files/publications/VEX_Status_Justification_Jun22.pdf)
+// so a suppression record can be promoted to a VEX statement later.
+type Justification string
+
+const (
+	ComponentNotPresent                         = Justification("component_not_present")
+	VulnerableCodeNotPresent                    = Justification("vulnerable_code_not_present")
+	VulnerableCodeNotInExecutePath              = Justification("vulnerable_code_not_in_execute_path")
+	VulnerableCodeCannotBeControlledByAdversary = Justification("vulnerable_code_cannot_be_controlled_by_adversary")
+	InlineMitigationsAlreadyExist               = Justification("inline_mitigations_already_exist")
+)
+
+// Record suppresses matching vulnerabilities until Expiry, if set.
+type Record struct {
+	VulnerabilityID string        `yaml:"vulnerabilityId" json:"vulnerabilityId"`
+	PkgName         string        `yaml:"pkgName,omitempty" json:"pkgName,omitempty"`
+	PkgVersionRange string        `yaml:"pkgVersionRange,omitempty" json:"pkgVersionRange,omitempty"`
+	Target          string        `yaml:"target,omitempty" json:"target,omitempty"` // glob, matched against Result.Target
+	TriageState     TriageState   `yaml:"triageState" json:"triageState"`
+	Justification   Justification `yaml:"justification,omitempty" json:"justification,omitempty"`
+	Expiry          *time.Time    `yaml:"expiry,omitempty" json:"expiry,omitempty"`
+	Notes           string        `yaml:"notes,omitempty" json:"notes,omitempty"`
+}
+
+// Database is a loaded suppression file.
+type Database struct {
+	Records []Record `yaml:"suppressions" json:"suppressions"`
+}
+
+// Load reads a suppression database from a YAML or JSON file, based on its
+// extension (defaulting to YAML).
+func Load(filePath string) (*Database, error) {
+	b, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read suppression file: %w", err)
+	}
+
+	var db Database
+	if filepath.Ext(filePath) == ".json" {
+		err = json.Unmarshal(b, &db)
+	} else {
+		err = yaml.Unmarshal(b, &db)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse suppression file %s: %w", filePath, err)
+	}
+	return &db, nil
+}
+
+// Apply filters results in place, moving every vulnerability matched by an
+// unexpired record into Suppressed and dropping it from Vulnerabilities.
+func (db *Database) Apply(results types.Results, now time.Time) types.Results {
+	for i, result := range results {
+		var kept []types.DetectedVulnerability
+		var suppressed []types.SuppressedVulnerability
+
+		for _, v := range result.Vulnerabilities {
+			record := db.match(result.Target, v, now)
+			if record == nil {
+				kept = append(kept, v)
+				continue
+			}
+			suppressed = append(suppressed, types.SuppressedVulnerability{
+				DetectedVulnerability: v,
+				TriageState:           string(record.TriageState),
+				Justification:         string(record.Justification),
+				Notes:                 record.Notes,
+			})
+		}
+
+		results[i].Vulnerabilities = kept
+		results[i].Suppressed = append(results[i].Suppressed, suppressed...)
+	}
+	return results
+}
+
+func (db *Database) match(target string, v types.DetectedVulnerability, now time.Time) *Record {
+	for i := range db.Records {
+		record := &db.Records[i]
+		if record.VulnerabilityID != v.VulnerabilityID {
+			continue
+		}
+		if record.Expiry != nil && now.After(*record.Expiry) {
+			continue
+		}
+		if record.PkgName != "" && record.PkgName != v.PkgName {
+			continue
+		}
+		if record.PkgVersionRange != "" && !versionInRange(v.InstalledVersion, record.PkgVersionRange) {
+			continue
+		}
+		if record.Target != "" {
+			if ok, err := path.Match(record.Target, target); err != nil || !ok {
+				continue
+			}
+		}
+		return record
+	}
+	return nil
+}
+
+// versionInRange supports the common ">= x, < y" shorthand used by most
+// suppression files: every comma-separated clause must hold for version to
+// be considered in range. A clause without a comparison operator is an
+// exact-version match. Anything more exotic (build metadata, pre-release
+// precedence, …) isn't handled and should pin an exact version instead.
+func versionInRange(version, rangeExpr string) bool {
+	for _, clause := range strings.Split(rangeExpr, ",") {
+		clause = strings.TrimSpace(clause)
+		if clause == "" {
+			continue
+		}
+
+		op, operand := splitOperator(clause)
+		if !compareVersion(version, op, operand) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitOperator splits a range clause such as ">= 1.0.0" into its comparison
+// operator and operand, defaulting to "=" when the clause is a bare version.
+func splitOperator(clause string) (op, operand string) {
+	for _, candidate := range []string{">=", "<=", "==", ">", "<", "="} {
+		if rest, ok := strings.CutPrefix(clause, candidate); ok {
+			return candidate, strings.TrimSpace(rest)
+		}
+	}
+	return "=", clause
+}
+
+func compareVersion(version, op, operand string) bool {
+	cmp := compareVersionSegments(version, operand)
+	switch op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default: // "=", "=="
+		return cmp == 0
+	}
+}
+
+// compareVersionSegments compares two dot-separated version strings
+// numerically segment by segment, treating a missing trailing segment as 0
+// and falling back to a lexical comparison for non-numeric segments (e.g.
+// pre-release suffixes).
+func compareVersionSegments(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		av, bv := "0", "0"
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+
+		an, aErr := strconv.Atoi(av)
+		bn, bErr := strconv.Atoi(bv)
+		if aErr == nil && bErr == nil {
+			if an != bn {
+				return an - bn
+			}
+			continue
+		}
+
+		if av != bv {
+			return strings.Compare(av, bv)
+		}
+	}
+	return 0
+}
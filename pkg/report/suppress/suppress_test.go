@@ -0,0 +1,121 @@
+package suppress_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/report/suppress"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestDatabase_Apply(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "suppress.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(`
+suppressions:
+  - vulnerabilityId: CVE-2021-0001
+    pkgName: foo
+    triageState: FalsePositive
+    justification: vulnerable_code_not_in_execute_path
+    notes: not reachable from any entrypoint
+  - vulnerabilityId: CVE-2021-9999
+    triageState: UnderInvestigation
+    expiry: 2000-01-01T00:00:00Z
+`), 0o600))
+
+	db, err := suppress.Load(yamlPath)
+	require.NoError(t, err)
+
+	results := types.Results{
+		{
+			Target: "test",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{VulnerabilityID: "CVE-2021-0001", PkgName: "foo"},
+				{VulnerabilityID: "CVE-2021-9999", PkgName: "bar"},
+				{VulnerabilityID: "CVE-2021-0002", PkgName: "baz"},
+			},
+		},
+	}
+
+	out := db.Apply(results, time.Now())
+	require.Len(t, out, 1)
+
+	// CVE-2021-0001 is suppressed, CVE-2021-9999's suppression already
+	// expired so it stays active, and CVE-2021-0002 was never listed.
+	assert.Len(t, out[0].Vulnerabilities, 2)
+	assert.Len(t, out[0].Suppressed, 1)
+	assert.Equal(t, "CVE-2021-0001", out[0].Suppressed[0].VulnerabilityID)
+	assert.Equal(t, "FalsePositive", out[0].Suppressed[0].TriageState)
+}
+
+func TestDatabase_Apply_VersionRange(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "suppress.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(`
+suppressions:
+  - vulnerabilityId: CVE-2022-0001
+    pkgName: foo
+    pkgVersionRange: ">= 1.0.0, < 2.0.0"
+    triageState: NotAffected
+`), 0o600))
+
+	db, err := suppress.Load(yamlPath)
+	require.NoError(t, err)
+
+	results := types.Results{
+		{
+			Target: "test",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{VulnerabilityID: "CVE-2022-0001", PkgName: "foo", InstalledVersion: "1.5.0"},
+				{VulnerabilityID: "CVE-2022-0001", PkgName: "foo", InstalledVersion: "2.0.0"},
+			},
+		},
+	}
+
+	out := db.Apply(results, time.Now())
+	require.Len(t, out, 1)
+
+	// 1.5.0 falls within the range and is suppressed; 2.0.0 is out of range and stays.
+	require.Len(t, out[0].Vulnerabilities, 1)
+	assert.Equal(t, "2.0.0", out[0].Vulnerabilities[0].InstalledVersion)
+	require.Len(t, out[0].Suppressed, 1)
+	assert.Equal(t, "1.5.0", out[0].Suppressed[0].InstalledVersion)
+}
+
+func TestDatabase_Apply_VersionRange_ExactMatchIgnoresTrailingZero(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "suppress.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte(`
+suppressions:
+  - vulnerabilityId: CVE-2022-0002
+    pkgName: foo
+    pkgVersionRange: "1.2"
+    triageState: NotAffected
+`), 0o600))
+
+	db, err := suppress.Load(yamlPath)
+	require.NoError(t, err)
+
+	results := types.Results{
+		{
+			Target: "test",
+			Vulnerabilities: []types.DetectedVulnerability{
+				{VulnerabilityID: "CVE-2022-0002", PkgName: "foo", InstalledVersion: "1.2.0"},
+			},
+		},
+	}
+
+	out := db.Apply(results, time.Now())
+	require.Len(t, out, 1)
+
+	// "1.2" and "1.2.0" are the same version; the missing trailing segment
+	// must compare equal to 0, not fail as a lexical mismatch.
+	assert.Empty(t, out[0].Vulnerabilities)
+	require.Len(t, out[0].Suppressed, 1)
+	assert.Equal(t, "1.2.0", out[0].Suppressed[0].InstalledVersion)
+}
@@ -0,0 +1,198 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const (
+	maxColumnWidth = 40
+)
+
+// TableWriter implements Writer and writes the scan results as a table.
+type TableWriter struct {
+	Output             io.Writer
+	IncludeNonFailures bool
+
+	// ShowSuppressed renders each Result's Suppressed vulnerabilities in a
+	// separate section, with the triage justification, instead of hiding them.
+	ShowSuppressed bool
+}
+
+// Write writes the results on the Output
+func (tw TableWriter) Write(report types.Report) error {
+	for _, result := range report.Results {
+		tw.write(result)
+	}
+	return nil
+}
+
+func (tw TableWriter) write(result types.Result) {
+	if len(result.Vulnerabilities) > 0 {
+		tw.writeVulnerabilities(result)
+	}
+	if len(result.Misconfigurations) > 0 {
+		tw.writeMisconfigurations(result)
+	}
+	if tw.ShowSuppressed && len(result.Suppressed) > 0 {
+		tw.writeSuppressed(result)
+	}
+}
+
+func (tw TableWriter) writeSuppressed(result types.Result) {
+	fmt.Fprintf(tw.Output, "\nSuppressed (%s):\n", result.Target)
+
+	t := newTableWriter(tw.Output)
+	t.SetHeader([]string{"Library", "Vulnerability ID", "Severity", "Triage State", "Justification", "Notes"})
+
+	for _, s := range result.Suppressed {
+		t.Append([]string{
+			s.PkgName,
+			s.VulnerabilityID,
+			s.Severity,
+			s.TriageState,
+			s.Justification,
+			s.Notes,
+		})
+	}
+	t.Render()
+}
+
+func (tw TableWriter) writeVulnerabilities(result types.Result) {
+	header := []string{"Library", "Vulnerability ID", "Severity", "Installed Version", "Fixed Version", "Title"}
+
+	t := newTableWriter(tw.Output)
+	t.SetHeader(header)
+	t.SetAutoMergeCellsByColumnIndex([]int{0})
+
+	for _, v := range result.Vulnerabilities {
+		lib := v.PkgName
+		if v.PkgPath != "" {
+			lib = fmt.Sprintf("%s (%s)", v.PkgName, pkgPathToDisplay(v.PkgPath))
+		}
+
+		title := v.Title
+		if title == "" {
+			title = v.Description
+		}
+		title = strings.TrimSpace(title)
+		if len(title) > maxColumnWidth {
+			title = fmt.Sprintf("%s...", title[:maxColumnWidth-3])
+		}
+		if v.PrimaryURL != "" {
+			title = fmt.Sprintf("%s\n-->%s", title, strings.TrimPrefix(v.PrimaryURL, "https://"))
+		}
+
+		t.Append([]string{
+			lib,
+			v.VulnerabilityID,
+			v.Severity,
+			v.InstalledVersion,
+			v.FixedVersion,
+			title,
+		})
+	}
+	t.Render()
+
+	writeOriginGraph(tw.Output, result)
+}
+
+func pkgPathToDisplay(pkgPath string) string {
+	// node_modules/foo/bar -> bar, but keep things simple for flat paths
+	parts := strings.Split(strings.TrimSuffix(pkgPath, "/package.json"), "/")
+	return parts[len(parts)-1]
+}
+
+func (tw TableWriter) writeMisconfigurations(result types.Result) {
+	header := []string{"Type", "Misconf ID", "Check", "Severity"}
+	if tw.IncludeNonFailures {
+		header = append(header, "Status")
+	}
+	header = append(header, "Message")
+
+	t := newTableWriter(tw.Output)
+	t.SetHeader(header)
+	t.SetAutoMergeCellsByColumnIndex([]int{0})
+
+	for _, m := range result.Misconfigurations {
+		if !tw.IncludeNonFailures && m.Status != types.StatusFailure {
+			continue
+		}
+
+		message := m.Message
+		if m.PrimaryURL != "" {
+			message = fmt.Sprintf("%s\n-->%s", message, strings.TrimPrefix(m.PrimaryURL, "https://"))
+		}
+
+		row := []string{m.Type, m.ID, m.Title, m.Severity}
+		if tw.IncludeNonFailures {
+			row = append(row, string(m.Status))
+		}
+		row = append(row, message)
+
+		t.Append(row)
+	}
+	t.Render()
+}
+
+func newTableWriter(output io.Writer) *tablewriter.Table {
+	t := tablewriter.NewWriter(output)
+	t.SetAutoWrapText(false)
+	t.SetAlignment(tablewriter.ALIGN_CENTER)
+	t.SetCenterSeparator("+")
+	t.SetRowLine(true)
+	return t
+}
+
+// writeOriginGraph renders the dependency chain that pulled in each
+// vulnerable package, when that information is available, as an ASCII tree
+// rooted at the scanned target.
+func writeOriginGraph(output io.Writer, result types.Result) {
+	roots := map[string][]*types.DependencyTreeItem{}
+	var pkgIDs []string
+	for _, v := range result.Vulnerabilities {
+		if v.PkgID == "" {
+			continue
+		}
+		if _, ok := roots[v.PkgID]; !ok {
+			pkgIDs = append(pkgIDs, v.PkgID)
+		}
+		// PkgParents is only used to draw children; a vulnerability with no
+		// recorded dependency chain still renders as a childless root.
+		roots[v.PkgID] = v.PkgParents
+	}
+	if len(pkgIDs) == 0 {
+		return
+	}
+	sort.Strings(pkgIDs)
+
+	fmt.Fprintf(output, "\nVulnerability origin graph:\n")
+	fmt.Fprintf(output, "===========================\n")
+	fmt.Fprintf(output, "%s\n", result.Target)
+
+	for i, pkgID := range pkgIDs {
+		last := i == len(pkgIDs)-1
+		writeTreeNode(output, "", pkgID, roots[pkgID], last)
+	}
+	fmt.Fprintln(output)
+}
+
+func writeTreeNode(output io.Writer, prefix, id string, parents []*types.DependencyTreeItem, last bool) {
+	connector := "├── "
+	childPrefix := prefix + "│   "
+	if last {
+		connector = "└── "
+		childPrefix = prefix + "    "
+	}
+	fmt.Fprintf(output, "%s%s%s\n", prefix, connector, id)
+
+	for i, p := range parents {
+		writeTreeNode(output, childPrefix, p.ID, p.Parents, i == len(parents)-1)
+	}
+}
@@ -0,0 +1,159 @@
+package report
+
+import (
+	"embed"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+//go:embed templates/*.tpl
+var builtinTemplateFS embed.FS
+
+// builtinTemplates maps a template name, as passed to --template, to the
+// asset shipped under templates/. Users can still point --template at a
+// filesystem path by prefixing it with "@", as before.
+var builtinTemplates = map[string]string{
+	"html":       "templates/html.tpl",
+	"html-dark":  "templates/html-dark.tpl",
+	"junit":      "templates/junit.tpl",
+	"gitlab":     "templates/gitlab.tpl",
+	"sarif-lite": "templates/sarif-lite.tpl",
+}
+
+// TemplateWriter implements Writer and renders the report through a Go
+// template, either one of the built-in named templates or a "@path" on disk.
+type TemplateWriter struct {
+	Output   io.Writer
+	Template string
+}
+
+// Write renders the report through the configured template on the Output.
+func (tw TemplateWriter) Write(report types.Report) error {
+	content, err := tw.loadTemplate()
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("output").Funcs(templateFuncMap()).Parse(content)
+	if err != nil {
+		return fmt.Errorf("error parsing template: %w", err)
+	}
+
+	if err := tmpl.Execute(tw.Output, report); err != nil {
+		return fmt.Errorf("error executing template: %w", err)
+	}
+	return nil
+}
+
+func (tw TemplateWriter) loadTemplate() (string, error) {
+	if path, ok := strings.CutPrefix(tw.Template, "@"); ok {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("error reading template %s: %w", path, err)
+		}
+		return string(b), nil
+	}
+
+	path, ok := builtinTemplates[tw.Template]
+	if !ok {
+		return "", fmt.Errorf("unknown template %q, expected one of html, html-dark, junit, gitlab, sarif-lite, or @path/to/tmpl", tw.Template)
+	}
+
+	b, err := builtinTemplateFS.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// targetGroup is a set of results sharing the same Target, the unit the
+// bundled HTML templates render one <tr class="group-header"> per.
+type targetGroup struct {
+	Target  string
+	Results types.Results
+}
+
+func groupByTarget(results types.Results) []targetGroup {
+	var order []string
+	groups := map[string]*targetGroup{}
+	for _, result := range results {
+		g, ok := groups[result.Target]
+		if !ok {
+			g = &targetGroup{Target: result.Target}
+			groups[result.Target] = g
+			order = append(order, result.Target)
+		}
+		g.Results = append(g.Results, result)
+	}
+
+	out := make([]targetGroup, 0, len(order))
+	for _, target := range order {
+		out = append(out, *groups[target])
+	}
+	return out
+}
+
+func templateFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"escapeXML":      escapeXML,
+		"severityColor":  severityColor,
+		"joinCSV":        joinCSV,
+		"getCurrentTime": getCurrentTime,
+		"groupByTarget":  groupByTarget,
+		"escapeJSON":     escapeJSON,
+	}
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		return s
+	}
+	return b.String()
+}
+
+// escapeJSON escapes a string for embedding inside a JSON string literal.
+// Templates that build JSON output (gitlab, sarif-lite) must use this
+// instead of escapeXML, which doesn't escape '"' or '\' and so can produce
+// invalid JSON for a title or message containing a quote.
+func escapeJSON(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return s
+	}
+	// Drop only the enclosing quotes Marshal added; strings.Trim would also
+	// eat an escaped quote at either end of s.
+	quoted := string(b)
+	return quoted[1 : len(quoted)-1]
+}
+
+func severityColor(severity string) string {
+	switch strings.ToUpper(severity) {
+	case "CRITICAL":
+		return "#951222"
+	case "HIGH":
+		return "#e2504d"
+	case "MEDIUM":
+		return "#ecc75e"
+	case "LOW":
+		return "#5fa8d3"
+	default:
+		return "#999999"
+	}
+}
+
+func joinCSV(items []string) string {
+	return strings.Join(items, ", ")
+}
+
+func getCurrentTime() string {
+	return time.Now().Format(time.RFC1123)
+}
@@ -0,0 +1,72 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestReportWriter_Template(t *testing.T) {
+	input := types.Report{
+		Results: types.Results{
+			{
+				Target: "test",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{
+						VulnerabilityID:  "CVE-2020-0001",
+						PkgName:          "foo",
+						InstalledVersion: "1.2.3",
+						FixedVersion:     "3.4.5",
+						PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2020-0001",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("built-in html template", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		err := report.Write(input, report.Option{Format: report.FormatTemplate, Template: "html", Output: &buf})
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "group-header")
+		assert.Contains(t, buf.String(), "CVE-2020-0001")
+	})
+
+	t.Run("unknown template name", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		err := report.Write(input, report.Option{Format: report.FormatTemplate, Template: "does-not-exist", Output: &buf})
+		assert.Error(t, err)
+	})
+
+	t.Run("sarif-lite template escapes quotes into valid JSON", func(t *testing.T) {
+		withQuotes := types.Report{
+			Results: types.Results{
+				{
+					Target: "test",
+					Vulnerabilities: []types.DetectedVulnerability{
+						{
+							VulnerabilityID: "CVE-2020-0002",
+							Vulnerability: dbTypes.Vulnerability{
+								Title: `uses "eval" unsafely`,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		buf := bytes.Buffer{}
+		err := report.Write(withQuotes, report.Option{Format: report.FormatTemplate, Template: "sarif-lite", Output: &buf})
+		require.NoError(t, err)
+
+		var parsed map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed), "sarif-lite output must be valid JSON: %s", buf.String())
+	})
+}
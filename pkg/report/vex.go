@@ -0,0 +1,174 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aquasecurity/trivy/pkg/report/suppress"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+const vexContext = "https://openvex.dev/ns/v0.2.0"
+
+// VEXStatus is one of the exploitability states defined by OpenVEX/CSAF-VEX.
+type VEXStatus string
+
+const (
+	VEXStatusNotAffected        = VEXStatus("not_affected")
+	VEXStatusAffected           = VEXStatus("affected")
+	VEXStatusFixed              = VEXStatus("fixed")
+	VEXStatusUnderInvestigation = VEXStatus("under_investigation")
+)
+
+type vexDocument struct {
+	Context    string         `json:"@context"`
+	ID         string         `json:"@id"`
+	Author     string         `json:"author"`
+	Version    int            `json:"version"`
+	Statements []vexStatement `json:"statements"`
+}
+
+type vexStatement struct {
+	Vulnerability   vexVulnerability `json:"vulnerability"`
+	Products        []vexProduct     `json:"products"`
+	Status          VEXStatus        `json:"status"`
+	Justification   string           `json:"justification,omitempty"`
+	ImpactStatement string           `json:"impact_statement,omitempty"`
+}
+
+type vexVulnerability struct {
+	Name string `json:"name"`
+}
+
+type vexProduct struct {
+	ID string `json:"@id"`
+}
+
+// VEXWriter implements Writer and emits an OpenVEX document describing the
+// exploitability status of each detected vulnerability.
+type VEXWriter struct {
+	Output io.Writer
+	Author string
+}
+
+// Write writes the report as an OpenVEX document on the Output.
+func (vw VEXWriter) Write(report types.Report) error {
+	doc := vw.marshal(report)
+	enc := json.NewEncoder(vw.Output)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func (vw VEXWriter) marshal(report types.Report) vexDocument {
+	author := vw.Author
+	if author == "" {
+		author = "Trivy"
+	}
+
+	doc := vexDocument{
+		Context: vexContext,
+		ID:      fmt.Sprintf("https://trivy.dev/vex/%s", report.ArtifactName),
+		Author:  author,
+		Version: 1,
+	}
+
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			doc.Statements = append(doc.Statements, vexStatementFor(v))
+		}
+		for _, v := range result.Suppressed {
+			doc.Statements = append(doc.Statements, vexStatementForSuppressed(v))
+		}
+	}
+	return doc
+}
+
+// vexStatementFor derives a statement for a live finding: a FixedVersion
+// means upstream already published a fix, so it's reported "fixed" rather
+// than "affected" even though Trivy still flags the installed version.
+func vexStatementFor(v types.DetectedVulnerability) vexStatement {
+	status := VEXStatusAffected
+	if v.FixedVersion != "" {
+		status = VEXStatusFixed
+	}
+
+	return vexStatement{
+		Vulnerability: vexVulnerability{Name: v.VulnerabilityID},
+		Products:      []vexProduct{{ID: vexProductID(v)}},
+		Status:        status,
+	}
+}
+
+// vexStatementForSuppressed maps a suppression record's TriageState to the
+// OpenVEX status it corresponds to, carrying over the record's own
+// Justification and Notes so a consumer reading the VEX document alone can
+// see why Trivy no longer reports the finding as exploitable.
+func vexStatementForSuppressed(v types.SuppressedVulnerability) vexStatement {
+	status := VEXStatusNotAffected
+	switch suppress.TriageState(v.TriageState) {
+	case suppress.Fixed:
+		status = VEXStatusFixed
+	case suppress.UnderInvestigation:
+		status = VEXStatusUnderInvestigation
+	}
+
+	return vexStatement{
+		Vulnerability:   vexVulnerability{Name: v.VulnerabilityID},
+		Products:        []vexProduct{{ID: vexProductID(v.DetectedVulnerability)}},
+		Status:          status,
+		Justification:   v.Justification,
+		ImpactStatement: v.Notes,
+	}
+}
+
+func vexProductID(v types.DetectedVulnerability) string {
+	productID := v.PkgID
+	if productID == "" {
+		productID = v.PkgName
+	}
+	if v.PkgPath != "" {
+		productID = fmt.Sprintf("%s#%s", productID, v.PkgPath)
+	}
+	return productID
+}
+
+// loadVEX reads an OpenVEX document from a file.
+func loadVEX(path string) (*vexDocument, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read VEX file: %w", err)
+	}
+
+	var doc vexDocument
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse VEX file %s: %w", path, err)
+	}
+	return &doc, nil
+}
+
+// applyVEX drops findings a VEX document declares "not_affected" or "fixed",
+// and keeps everything else (including "affected"/"under_investigation")
+// exactly as detected.
+func applyVEX(results types.Results, doc *vexDocument) types.Results {
+	statuses := map[string]VEXStatus{}
+	for _, s := range doc.Statements {
+		for _, p := range s.Products {
+			statuses[s.Vulnerability.Name+"\x00"+p.ID] = s.Status
+		}
+	}
+
+	for i, result := range results {
+		var kept []types.DetectedVulnerability
+		for _, v := range result.Vulnerabilities {
+			status, ok := statuses[v.VulnerabilityID+"\x00"+vexProductID(v)]
+			if ok && (status == VEXStatusNotAffected || status == VEXStatusFixed) {
+				continue
+			}
+			kept = append(kept, v)
+		}
+		results[i].Vulnerabilities = kept
+	}
+	return results
+}
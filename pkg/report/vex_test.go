@@ -0,0 +1,105 @@
+package report_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/trivy/pkg/report"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+func TestReportWriter_VEX(t *testing.T) {
+	input := types.Report{
+		ArtifactName: "alpine:3.16",
+		Results: types.Results{
+			{
+				Target: "alpine:3.16 (alpine 3.16.2)",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2022-1234", PkgID: "musl@1.2.3", PkgName: "musl"},
+					{VulnerabilityID: "CVE-2022-5678", PkgID: "busybox@1.35.0", PkgName: "busybox", FixedVersion: "1.36.0"},
+				},
+			},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	err := report.Write(input, report.Option{Format: report.FormatVEX, Output: &buf})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `"name": "CVE-2022-1234"`)
+	assert.Contains(t, out, `"status": "affected"`)
+	assert.Contains(t, out, `"status": "fixed"`)
+}
+
+func TestReportWriter_VEX_AuthorAndSuppressed(t *testing.T) {
+	input := types.Report{
+		ArtifactName: "alpine:3.16",
+		Results: types.Results{
+			{
+				Target: "alpine:3.16 (alpine 3.16.2)",
+				Suppressed: []types.SuppressedVulnerability{
+					{
+						DetectedVulnerability: types.DetectedVulnerability{
+							VulnerabilityID: "CVE-2022-9999",
+							PkgID:           "musl@1.2.3",
+							PkgName:         "musl",
+						},
+						TriageState:   "FalsePositive",
+						Justification: "vulnerable_code_not_in_execute_path",
+						Notes:         "not reachable from any entrypoint",
+					},
+				},
+			},
+		},
+	}
+
+	buf := bytes.Buffer{}
+	err := report.Write(input, report.Option{Format: report.FormatVEX, Output: &buf, VEXAuthor: "acme-security"})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, `"author": "acme-security"`)
+	assert.Contains(t, out, `"name": "CVE-2022-9999"`)
+	assert.Contains(t, out, `"status": "not_affected"`)
+	assert.Contains(t, out, `"justification": "vulnerable_code_not_in_execute_path"`)
+	assert.Contains(t, out, `"impact_statement": "not reachable from any entrypoint"`)
+}
+
+func TestReportWriter_VEXFile(t *testing.T) {
+	input := types.Report{
+		Results: types.Results{
+			{
+				Target: "alpine:3.16",
+				Vulnerabilities: []types.DetectedVulnerability{
+					{VulnerabilityID: "CVE-2022-1234", PkgID: "musl@1.2.3", PkgName: "musl"},
+					{VulnerabilityID: "CVE-2022-5678", PkgID: "busybox@1.35.0", PkgName: "busybox"},
+				},
+			},
+		},
+	}
+
+	vexPath := filepath.Join(t.TempDir(), "vex.json")
+	require.NoError(t, os.WriteFile(vexPath, []byte(`{
+		"@context": "https://openvex.dev/ns/v0.2.0",
+		"@id": "https://example.com/vex",
+		"author": "acme",
+		"version": 1,
+		"statements": [
+			{"vulnerability": {"name": "CVE-2022-1234"}, "products": [{"@id": "musl@1.2.3"}], "status": "not_affected"}
+		]
+	}`), 0o600))
+
+	buf := bytes.Buffer{}
+	err := report.Write(input, report.Option{Format: report.FormatJSON, Output: &buf, VEXFile: vexPath})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.NotContains(t, out, "CVE-2022-1234")
+	assert.Contains(t, out, "CVE-2022-5678")
+}
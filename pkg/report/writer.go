@@ -0,0 +1,119 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aquasecurity/trivy/pkg/report/suppress"
+	"github.com/aquasecurity/trivy/pkg/types"
+)
+
+// Output formats supported by Write.
+const (
+	FormatTable         = "table"
+	FormatJSON          = "json"
+	FormatTemplate      = "template"
+	FormatCycloneDX     = "cyclonedx"
+	FormatCycloneDXJSON = "cyclonedx-json"
+	FormatSPDXJSON      = "spdx-json"
+	FormatSPDXTagValue  = "spdx-tag-value"
+	FormatDiff          = "diff"
+	FormatGitHub        = "github"
+	FormatVEX           = "vex"
+)
+
+// Option holds the configuration for Write.
+type Option struct {
+	AppVersion string
+
+	Format string
+	Output io.Writer
+
+	Template string
+
+	IncludeNonFailures bool
+
+	// BaseReport is the report the revised report is compared against.
+	// When set, Write runs Diff whatever the Format; FormatDiff requires it.
+	BaseReport *types.Report
+
+	// SuppressFile, when set, points at a suppress.Database file that is
+	// applied to the report before writing. ShowSuppressed additionally
+	// renders the suppressed findings, with their triage justification,
+	// in a dedicated section instead of hiding them entirely.
+	SuppressFile   string
+	ShowSuppressed bool
+
+	// VEXFile, when set, points at an OpenVEX document applied before the
+	// report is written: findings it declares "not_affected" or "fixed" are
+	// removed, and findings with any other status are kept unchanged (their
+	// severity is not downgraded).
+	VEXFile string
+
+	// VEXAuthor identifies the issuer of a generated OpenVEX document
+	// (FormatVEX), defaulting to "Trivy" when unset.
+	VEXAuthor string
+}
+
+// Writer defines the result write operation.
+type Writer interface {
+	Write(types.Report) error
+}
+
+// Write writes the scan results to the writer configured by Option.Format.
+func Write(report types.Report, option Option) error {
+	// BaseReport marks a diff regardless of which render Format it's paired
+	// with: Diff itself switches on opt.Format to pick table/JSON/GitHub
+	// summary rendering, so FormatJSON and FormatGitHub reach it here too
+	// instead of falling through to the non-diff writer switch below.
+	if option.BaseReport != nil {
+		return Diff(*option.BaseReport, report, option)
+	}
+	if option.Format == FormatDiff {
+		return fmt.Errorf("diff format requires option.BaseReport")
+	}
+
+	if option.SuppressFile != "" {
+		db, err := suppress.Load(option.SuppressFile)
+		if err != nil {
+			return fmt.Errorf("failed to load suppression file: %w", err)
+		}
+		report.Results = db.Apply(report.Results, time.Now())
+	}
+
+	if option.VEXFile != "" {
+		vexDoc, err := loadVEX(option.VEXFile)
+		if err != nil {
+			return fmt.Errorf("failed to load VEX file: %w", err)
+		}
+		report.Results = applyVEX(report.Results, vexDoc)
+	}
+
+	var writer Writer
+	switch option.Format {
+	case FormatTable:
+		writer = TableWriter{
+			Output:             option.Output,
+			IncludeNonFailures: option.IncludeNonFailures,
+			ShowSuppressed:     option.ShowSuppressed,
+		}
+	case FormatJSON:
+		writer = JSONWriter{Output: option.Output, ShowSuppressed: option.ShowSuppressed}
+	case FormatCycloneDX, FormatCycloneDXJSON:
+		writer = CycloneDXWriter{Output: option.Output, Format: option.Format}
+	case FormatSPDXJSON, FormatSPDXTagValue:
+		writer = SPDXWriter{Output: option.Output, Format: option.Format}
+	case FormatTemplate:
+		writer = TemplateWriter{Output: option.Output, Template: option.Template}
+	case FormatVEX:
+		writer = VEXWriter{Output: option.Output, Author: option.VEXAuthor}
+	default:
+		return fmt.Errorf("unknown format: %v", option.Format)
+	}
+
+	if err := writer.Write(report); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+	return nil
+}
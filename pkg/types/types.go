@@ -0,0 +1,145 @@
+package types
+
+import (
+	dbTypes "github.com/aquasecurity/trivy-db/pkg/types"
+)
+
+// Status represents the state of a misconfiguration check.
+type Status string
+
+const (
+	StatusFailure   = Status("FAIL")
+	StatusPassed    = Status("PASS")
+	StatusException = Status("EXCEPTION")
+)
+
+// ResultClass represents the class of a scan result, e.g. language packages,
+// OS packages, configuration files, secrets, etc.
+type ResultClass string
+
+const (
+	ClassOSPkg   = ResultClass("os-pkgs")
+	ClassLangPkg = ResultClass("lang-pkgs")
+	ClassConfig  = ResultClass("config")
+	ClassSecret  = ResultClass("secret")
+	ClassLicense = ResultClass("license")
+	ClassCustom  = ResultClass("custom")
+)
+
+// Layer holds the image layer a package or vulnerability was detected in.
+type Layer struct {
+	Digest string `json:",omitempty"`
+	DiffID string `json:",omitempty"`
+}
+
+// DependencyTreeItem is a node in the dependency tree leading to a package,
+// used to render the "Vulnerability origin graph" in the table writer.
+type DependencyTreeItem struct {
+	ID      string
+	Parents []*DependencyTreeItem
+}
+
+// Package represents a package detected in the scanned artifact.
+type Package struct {
+	ID       string   `json:",omitempty"`
+	Name     string
+	Version  string
+	Release  string   `json:",omitempty"`
+	Epoch    int      `json:",omitempty"`
+	Arch     string   `json:",omitempty"`
+	PkgPath  string   `json:",omitempty"`
+	Licenses []string `json:",omitempty"`
+	Layer    Layer    `json:",omitempty"`
+
+	// LicenseConcluded and LicenseDeclared follow the SPDX notion of a
+	// license actually found in the package versus the one the package
+	// metadata claims. They are not filled in from Licenses; when empty,
+	// the SPDX writer reports them as NOASSERTION.
+	LicenseConcluded string `json:",omitempty"`
+	LicenseDeclared  string `json:",omitempty"`
+}
+
+// DetectedVulnerability holds the information of a detected vulnerability.
+type DetectedVulnerability struct {
+	VulnerabilityID  string
+	PkgID            string `json:",omitempty"`
+	PkgName          string
+	PkgPath          string `json:",omitempty"`
+	InstalledVersion string
+	FixedVersion     string
+	PrimaryURL       string `json:",omitempty"`
+
+	// PkgParents holds the dependency chain(s) that pulled this package in,
+	// rendered as the "Vulnerability origin graph" by the table writer.
+	PkgParents []*DependencyTreeItem `json:",omitempty"`
+
+	Layer Layer `json:",omitempty"`
+
+	dbTypes.Vulnerability
+}
+
+// DetectedMisconfiguration holds the information of a detected misconfiguration.
+type DetectedMisconfiguration struct {
+	Type        string
+	ID          string
+	Title       string
+	Description string `json:",omitempty"`
+	Message     string
+	Namespace   string `json:",omitempty"`
+	Query       string `json:",omitempty"`
+	Resolution  string `json:",omitempty"`
+	Severity    string
+	PrimaryURL  string
+
+	Status Status
+
+	CauseMetadata CauseMetadata `json:",omitempty"`
+}
+
+// CauseMetadata holds the location of a misconfiguration within a file.
+type CauseMetadata struct {
+	Resource  string `json:",omitempty"`
+	Provider  string `json:",omitempty"`
+	Service   string `json:",omitempty"`
+	StartLine int    `json:",omitempty"`
+	EndLine   int    `json:",omitempty"`
+}
+
+// Result holds a set of detection results for a single target, e.g. an image
+// layer, a lockfile, or a Kubernetes manifest.
+type Result struct {
+	Target            string
+	Class             ResultClass                `json:",omitempty"`
+	Type              string                     `json:",omitempty"`
+	Packages          []Package                  `json:",omitempty"`
+	Vulnerabilities   []DetectedVulnerability    `json:",omitempty"`
+	Misconfigurations []DetectedMisconfiguration `json:",omitempty"`
+
+	// Suppressed holds vulnerabilities that were filtered out of
+	// Vulnerabilities by a suppression database instead of being dropped
+	// outright, so audits can still see why a finding was hidden.
+	Suppressed []SuppressedVulnerability `json:",omitempty"`
+}
+
+// SuppressedVulnerability is a DetectedVulnerability that a suppression
+// record triaged out of the active findings, along with the record's own
+// reasoning for doing so.
+type SuppressedVulnerability struct {
+	DetectedVulnerability
+
+	TriageState   string `json:"triageState"`
+	Justification string `json:",omitempty"`
+	Notes         string `json:",omitempty"`
+}
+
+// Results is a collection of scan results.
+type Results []Result
+
+// Report represents a whole scan result, covering every target that was
+// scanned together, e.g. every layer of a container image.
+type Report struct {
+	SchemaVersion int    `json:",omitempty"`
+	ArtifactName  string `json:",omitempty"`
+	ArtifactType  string `json:",omitempty"`
+	Results       Results
+}